@@ -2,23 +2,49 @@ package hcloud
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/docker/machine/libmachine/drivers"
 	"github.com/docker/machine/libmachine/mcnflag"
+	"github.com/docker/machine/libmachine/ssh"
 	"github.com/docker/machine/libmachine/state"
 	"github.com/docker/machine/version"
 	"github.com/hetznercloud/hcloud-go/hcloud"
+	xssh "golang.org/x/crypto/ssh"
 )
 
 const (
 	driverName        = "hcloud"
 	defaultImage      = "ubuntu-18.04"
 	defaultServerType = "cx11"
+
+	defaultPoweronTimeout = 3 * time.Minute
+	poweronPollInterval   = 5 * time.Second
+	poweronMaxRetries     = 3
+
+	defaultRescueOSType = "linux64"
+
+	// ephemeralPlacementGroupLabel marks a placement group as having been
+	// created by this driver (as opposed to a pre-existing, human-managed
+	// one resolved by name/ID), so Remove knows it is safe to delete once
+	// empty.
+	ephemeralPlacementGroupLabel = "docker-machine-ephemeral"
 )
 
+// ErrServerStuckOff is returned by Start when the HCloud API reports a
+// successful Poweron action but the server never transitions out of
+// ServerStatusOff before the poweron timeout elapses.
+var ErrServerStuckOff = errors.New("hcloud: server is stuck off after poweron")
+
 type Driver struct {
 	*drivers.BaseDriver
 	serverId   int
@@ -27,10 +53,34 @@ type Driver struct {
 	Datacenter string
 	ServerType string
 	hcloud     *hcloud.Client
+
+	PrivateNetwork   string
+	UsePrivateIP     bool
+	IPv6Only         bool
+	PrivateIPAddress string
+	IPv6Address      string
+
+	PoweronTimeout time.Duration
+
+	SSHKeyIDs       []int
+	SSHKeyNames     []string
+	ExistingKeyPath string
+	sshKeyId        int
+
+	UserData     string
+	UserDataFile string
+
+	PlacementGroup string
+	Labels         []string
+	Firewalls      []string
+
+	placementGroupId int
+
+	RescueOnFailure bool
 }
 
 func (d *Driver) GetSSHHostname() (string, error) {
-	return d.GetIP()
+	return d.resolveIPAddress()
 }
 
 func (d *Driver) GetURL() (string, error) {
@@ -38,7 +88,7 @@ func (d *Driver) GetURL() (string, error) {
 		return "", err
 	}
 
-	ip, err := d.GetIP()
+	ip, err := d.resolveIPAddress()
 	if err != nil {
 		return "", err
 	}
@@ -46,6 +96,28 @@ func (d *Driver) GetURL() (string, error) {
 	return fmt.Sprintf("tcp://%s", net.JoinHostPort(ip, "2376")), nil
 }
 
+// resolveIPAddress picks which address family to use for SSH/Docker
+// connections, preferring the private network IP or IPv6 address when the
+// driver was configured to do so, and falling back to the public IPv4
+// address otherwise.
+func (d *Driver) resolveIPAddress() (string, error) {
+	if d.UsePrivateIP {
+		if d.PrivateIPAddress == "" {
+			return "", fmt.Errorf("hcloud: no private IP address available for %s", d.MachineName)
+		}
+		return d.PrivateIPAddress, nil
+	}
+
+	if d.IPv6Only {
+		if d.IPv6Address == "" {
+			return "", fmt.Errorf("hcloud: no IPv6 address available for %s", d.MachineName)
+		}
+		return d.IPv6Address, nil
+	}
+
+	return d.GetIP()
+}
+
 func NewDriver(hostName, storePath string) *Driver {
 	return &Driver{
 		BaseDriver: &drivers.BaseDriver{
@@ -84,6 +156,72 @@ func (d *Driver) GetCreateFlags() []mcnflag.Flag {
 			Name:   "hcloud-datacenter",
 			Usage:  "URL of host when no driver is selected",
 		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_PRIVATE_NETWORK",
+			Name:   "hcloud-private-network",
+			Usage:  "Name or ID of an existing Hetzner Cloud private network to attach the server to",
+		},
+		mcnflag.BoolFlag{
+			EnvVar: "HCLOUD_USE_PRIVATE_IP",
+			Name:   "hcloud-use-private-ip",
+			Usage:  "Use the private network IP for SSH and Docker connections",
+		},
+		mcnflag.BoolFlag{
+			EnvVar: "HCLOUD_IPV6_ONLY",
+			Name:   "hcloud-ipv6-only",
+			Usage:  "Use the public IPv6 address for SSH and Docker connections",
+		},
+		mcnflag.IntFlag{
+			EnvVar: "HCLOUD_POWERON_TIMEOUT",
+			Name:   "hcloud-poweron-timeout",
+			Usage:  "Total seconds to wait across all poweron retries for a server to leave the off state",
+			Value:  int(defaultPoweronTimeout.Seconds()),
+		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_SSH_KEY_IDS",
+			Name:   "hcloud-ssh-key-ids",
+			Usage:  "Comma-separated list of existing Hetzner Cloud SSH key IDs to add to the server",
+		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_SSH_KEY_NAMES",
+			Name:   "hcloud-ssh-key-names",
+			Usage:  "Comma-separated list of existing Hetzner Cloud SSH key names to add to the server",
+		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_EXISTING_KEY_PATH",
+			Name:   "hcloud-existing-key-path",
+			Usage:  "Path to an existing private key to upload and use instead of generating a new one",
+		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_USER_DATA",
+			Name:   "hcloud-user-data",
+			Usage:  "Cloud-init user-data to apply to the server on first boot",
+		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_USER_DATA_FILE",
+			Name:   "hcloud-user-data-file",
+			Usage:  "Path to a file containing cloud-init user-data to apply to the server on first boot",
+		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_PLACEMENT_GROUP",
+			Name:   "hcloud-placement-group",
+			Usage:  "Name or ID of a placement group to add the server to, created if it does not already exist",
+		},
+		mcnflag.StringSliceFlag{
+			EnvVar: "HCLOUD_LABELS",
+			Name:   "hcloud-labels",
+			Usage:  "Labels to apply to the server, in key=value form (can be specified multiple times)",
+		},
+		mcnflag.StringFlag{
+			EnvVar: "HCLOUD_FIREWALLS",
+			Name:   "hcloud-firewalls",
+			Usage:  "Comma-separated list of firewall names or IDs to attach to the server",
+		},
+		mcnflag.BoolFlag{
+			EnvVar: "HCLOUD_RESCUE_ON_FAILURE",
+			Name:   "hcloud-rescue-on-failure",
+			Usage:  "Boot the server into rescue mode automatically if it gets stuck during provisioning",
+		},
 	}
 }
 
@@ -97,32 +235,295 @@ func (d *Driver) Create() error {
 	if err != nil {
 		return err
 	}
+
+	sshKeys, err := d.resolveSSHKeys()
+	if err != nil {
+		return err
+	}
+
+	userData, err := d.resolveUserData()
+	if err != nil {
+		return err
+	}
+
+	labels, err := d.resolveLabels()
+	if err != nil {
+		return err
+	}
+
+	firewalls, err := d.resolveFirewalls()
+	if err != nil {
+		return err
+	}
+
 	opts := hcloud.ServerCreateOpts{
 		Name:       d.BaseDriver.MachineName,
 		ServerType: serverType,
 		Image:      image,
+		SSHKeys:    sshKeys,
+		UserData:   userData,
+		Labels:     labels,
+		Firewalls:  firewalls,
+	}
+
+	if d.PlacementGroup != "" {
+		placementGroup, err := d.resolvePlacementGroup()
+		if err != nil {
+			return err
+		}
+		opts.PlacementGroup = placementGroup
 	}
 	if d.Datacenter != "" {
-		datacenter, _, err := d.hcloud.Datacenter.Get(context.TODO(), d.Image)
+		datacenter, _, err := d.hcloud.Datacenter.Get(context.TODO(), d.Datacenter)
 		if err != nil {
 			return err
 		}
 		opts.Datacenter = datacenter
 	}
 	if d.Location != "" {
-		location, _, err := d.hcloud.Location.Get(context.TODO(), d.Image)
+		location, _, err := d.hcloud.Location.Get(context.TODO(), d.Location)
 		if err != nil {
 			return err
 		}
 		opts.Location = location
 	}
+	if d.PrivateNetwork != "" {
+		network, _, err := d.hcloud.Network.Get(context.TODO(), d.PrivateNetwork)
+		if err != nil {
+			return err
+		}
+		if network == nil {
+			return fmt.Errorf("hcloud: private network %q not found", d.PrivateNetwork)
+		}
+		opts.Networks = []*hcloud.Network{network}
+	}
 	resp, _, err := d.hcloud.Server.Create(context.TODO(), opts)
 	d.waitOnAction(resp.Action)
 	d.serverId = resp.Server.ID
 	d.IPAddress = resp.Server.PublicNet.IPv4.IP.String()
+	if resp.Server.PublicNet.IPv6.IP != nil {
+		d.IPv6Address = ipv6HostAddress(resp.Server.PublicNet.IPv6.IP)
+	}
+	if len(resp.Server.PrivateNet) > 0 {
+		d.PrivateIPAddress = resp.Server.PrivateNet[0].IP.String()
+	}
 	return nil
 }
 
+// resolveSSHKeys looks up the SSH keys configured via --hcloud-ssh-key-ids
+// and --hcloud-ssh-key-names. If none were given, it uploads the keypair at
+// --hcloud-existing-key-path, or generates a new one at d.GetSSHKeyPath()
+// otherwise, so that cloud-init can configure SSH access without falling
+// back to a root password.
+func (d *Driver) resolveSSHKeys() ([]*hcloud.SSHKey, error) {
+	var sshKeys []*hcloud.SSHKey
+
+	for _, id := range d.SSHKeyIDs {
+		key, _, err := d.hcloud.SSHKey.GetByID(context.TODO(), id)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			return nil, fmt.Errorf("hcloud: ssh key with ID %d not found", id)
+		}
+		sshKeys = append(sshKeys, key)
+	}
+
+	for _, name := range d.SSHKeyNames {
+		key, _, err := d.hcloud.SSHKey.GetByName(context.TODO(), name)
+		if err != nil {
+			return nil, err
+		}
+		if key == nil {
+			return nil, fmt.Errorf("hcloud: ssh key %q not found", name)
+		}
+		sshKeys = append(sshKeys, key)
+	}
+
+	if len(sshKeys) > 0 {
+		return sshKeys, nil
+	}
+
+	key, err := d.uploadSSHKey()
+	if err != nil {
+		return nil, err
+	}
+	return []*hcloud.SSHKey{key}, nil
+}
+
+// uploadSSHKey creates an SSH key through the Hetzner Cloud API so it can be
+// injected into the server at create time, generating a fresh keypair unless
+// --hcloud-existing-key-path was given. If a key with the same public key
+// fingerprint already exists - as happens when --hcloud-existing-key-path
+// points multiple nodes at the same local keypair - that key is reused
+// instead of re-uploaded, since Hetzner Cloud rejects a duplicate public
+// key. Only a key this call actually creates is tracked on d.sshKeyId for
+// cleanup by Remove; a reused key is left alone since other nodes may still
+// depend on it.
+func (d *Driver) uploadSSHKey() (*hcloud.SSHKey, error) {
+	keyPath := d.ExistingKeyPath
+	if keyPath == "" {
+		if err := ssh.GenerateSSHKey(d.GetSSHKeyPath()); err != nil {
+			return nil, err
+		}
+		keyPath = d.GetSSHKeyPath()
+	} else {
+		d.SSHKeyPath = keyPath
+	}
+
+	publicKey, err := ioutil.ReadFile(keyPath + ".pub")
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint, err := publicKeyFingerprint(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := d.findSSHKeyByFingerprint(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	key, _, err := d.hcloud.SSHKey.Create(context.TODO(), hcloud.SSHKeyCreateOpts{
+		Name:      d.MachineName,
+		PublicKey: string(publicKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.sshKeyId = key.ID
+	return key, nil
+}
+
+// findSSHKeyByFingerprint returns the existing Hetzner Cloud SSH key whose
+// fingerprint matches, or nil if none does.
+func (d *Driver) findSSHKeyByFingerprint(fingerprint string) (*hcloud.SSHKey, error) {
+	keys, err := d.hcloud.SSHKey.All(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		if key.Fingerprint == fingerprint {
+			return key, nil
+		}
+	}
+	return nil, nil
+}
+
+// publicKeyFingerprint computes the MD5 fingerprint of an authorized_keys
+// formatted public key, in the colon-separated hex form Hetzner Cloud
+// reports as SSHKey.Fingerprint.
+func publicKeyFingerprint(publicKey []byte) (string, error) {
+	parsed, _, _, _, err := xssh.ParseAuthorizedKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	sum := md5.Sum(parsed.Marshal())
+	hexSum := hex.EncodeToString(sum[:])
+
+	parts := make([]string, len(hexSum)/2)
+	for i := range parts {
+		parts[i] = hexSum[i*2 : i*2+2]
+	}
+	return strings.Join(parts, ":"), nil
+}
+
+// resolveUserData returns the cloud-init user-data to pass to the server,
+// preferring the contents of --hcloud-user-data-file when both it and
+// --hcloud-user-data are set. This lets non-default images like Flatcar or
+// Talos, which docker-machine's default provisioner cannot SSH into until
+// cloud-init has run, bootstrap themselves on first boot.
+func (d *Driver) resolveUserData() (string, error) {
+	if d.UserDataFile != "" {
+		content, err := ioutil.ReadFile(d.UserDataFile)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+	return d.UserData, nil
+}
+
+// resolvePlacementGroup looks up --hcloud-placement-group by name or ID,
+// creating a new spread placement group labelled as ephemeral if none
+// exists yet. The resolved ID is stored on the Driver so that Remove can
+// delete the group once this server is no longer its last member - but
+// only if it carries the ephemeral label, so a pre-existing, human-managed
+// group a node was merely slotted into is never deleted out from under it.
+func (d *Driver) resolvePlacementGroup() (*hcloud.PlacementGroup, error) {
+	placementGroup, _, err := d.hcloud.PlacementGroup.Get(context.TODO(), d.PlacementGroup)
+	if err != nil {
+		return nil, err
+	}
+	if placementGroup != nil {
+		d.placementGroupId = placementGroup.ID
+		return placementGroup, nil
+	}
+
+	result, _, err := d.hcloud.PlacementGroup.Create(context.TODO(), hcloud.PlacementGroupCreateOpts{
+		Name:   d.PlacementGroup,
+		Type:   hcloud.PlacementGroupTypeSpread,
+		Labels: map[string]string{ephemeralPlacementGroupLabel: "true"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.placementGroupId = result.PlacementGroup.ID
+	return result.PlacementGroup, nil
+}
+
+// resolveLabels parses --hcloud-labels key=value pairs into the map shape
+// expected by ServerCreateOpts.
+func (d *Driver) resolveLabels() (map[string]string, error) {
+	if len(d.Labels) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(d.Labels))
+	for _, label := range d.Labels {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("--hcloud-labels: invalid label %q, expected key=value", label)
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return labels, nil
+}
+
+// resolveFirewalls looks up --hcloud-firewalls by name or ID so they can be
+// attached to the server at create time.
+func (d *Driver) resolveFirewalls() ([]*hcloud.ServerCreateFirewall, error) {
+	var firewalls []*hcloud.ServerCreateFirewall
+	for _, ref := range d.Firewalls {
+		firewall, _, err := d.hcloud.Firewall.Get(context.TODO(), ref)
+		if err != nil {
+			return nil, err
+		}
+		if firewall == nil {
+			return nil, fmt.Errorf("hcloud: firewall %q not found", ref)
+		}
+		firewalls = append(firewalls, &hcloud.ServerCreateFirewall{Firewall: *firewall})
+	}
+	return firewalls, nil
+}
+
+// ipv6HostAddress derives the first usable host address from the /64
+// network address Hetzner Cloud assigns as a server's public IPv6 address.
+func ipv6HostAddress(network net.IP) string {
+	ip := make(net.IP, len(network))
+	copy(ip, network)
+	ip[len(ip)-1] |= 1
+	return ip.String()
+}
+
 // DriverName returns the name of the driver
 func (d *Driver) DriverName() string {
 	return driverName
@@ -164,12 +565,45 @@ func (d *Driver) Remove() error {
 	if err != nil {
 		if hcloud.IsError(err, hcloud.ErrorCodeNotFound) {
 			log.Printf("HCloud server does not exists.")
-			return nil
+		} else {
+			return err
+		}
+	} else {
+		if _, err := d.hcloud.Server.Delete(context.TODO(), server); err != nil {
+			return err
+		}
+	}
+
+	if d.sshKeyId != 0 {
+		sshKey, _, err := d.hcloud.SSHKey.GetByID(context.TODO(), d.sshKeyId)
+		if err != nil {
+			return err
+		}
+		if sshKey != nil {
+			if _, err := d.hcloud.SSHKey.Delete(context.TODO(), sshKey); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.placementGroupId != 0 {
+		placementGroup, _, err := d.hcloud.PlacementGroup.GetByID(context.TODO(), d.placementGroupId)
+		if err != nil {
+			return err
+		}
+		// The server was already removed from the group above, so an empty
+		// Servers list here means this was the last member. Only delete it
+		// if docker-machine created it (the ephemeral label), so a
+		// pre-existing, human-managed group a node was merely slotted into
+		// is never deleted out from under it.
+		if placementGroup != nil && placementGroup.Labels[ephemeralPlacementGroupLabel] == "true" && len(placementGroup.Servers) == 0 {
+			if _, err := d.hcloud.PlacementGroup.Delete(context.TODO(), placementGroup); err != nil {
+				return err
+			}
 		}
-		return err
 	}
-	_, err = d.hcloud.Server.Delete(context.TODO(), server)
-	return err
+
+	return nil
 }
 
 func (d *Driver) Restart() error {
@@ -184,6 +618,55 @@ func (d *Driver) Restart() error {
 	return d.waitOnAction(action)
 }
 
+// Rescue powers the server off, enables rescue mode with the given OS
+// (typically "linux64" or "linux32"), and reboots it so the server comes
+// back up with a rescue system and root credentials that allow recovering
+// a stuck node without manual Hetzner Console intervention. Start calls
+// this automatically when --hcloud-rescue-on-failure is set and the server
+// is still off after exhausting its poweron retries.
+//
+// NOTE: this driver package cannot add the standalone
+// `docker-machine hcloud-rescue <machine>` plugin subcommand the backlog
+// item asked for - that requires registering a new CLI command in
+// docker-machine's own commands package, which does not live in this
+// drivers/hcloud tree and is out of scope for a change confined to this
+// file. Rescue is therefore only reachable via Start's automatic
+// stuck-off recovery path, not as an operator-triggered command.
+func (d *Driver) Rescue(osType string) error {
+	server, _, err := d.hcloud.Server.GetByID(context.TODO(), d.serverId)
+	if err != nil {
+		return err
+	}
+
+	if server.Status != hcloud.ServerStatusOff {
+		action, _, err := d.hcloud.Server.Poweroff(context.TODO(), server)
+		if err != nil {
+			return err
+		}
+		if err := d.waitOnAction(action); err != nil {
+			return err
+		}
+	}
+
+	result, _, err := d.hcloud.Server.EnableRescue(context.TODO(), server, hcloud.ServerEnableRescueOpts{
+		Type: hcloud.ServerRescueType(osType),
+	})
+	if err != nil {
+		return err
+	}
+	if err := d.waitOnAction(result.Action); err != nil {
+		return err
+	}
+
+	log.Printf("HCloud rescue mode enabled for server %d, root password: %s", server.ID, result.RootPassword)
+
+	action, _, err := d.hcloud.Server.Poweron(context.TODO(), server)
+	if err != nil {
+		return err
+	}
+	return d.waitOnAction(action)
+}
+
 func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 	token := flags.String("hcloud-token")
 
@@ -206,6 +689,45 @@ func (d *Driver) SetConfigFromFlags(flags drivers.DriverOptions) error {
 	d.ServerType = serverType
 	d.Datacenter = flags.String("hcloud-datacenter")
 	d.Location = flags.String("hcloud-location")
+	d.PrivateNetwork = flags.String("hcloud-private-network")
+	d.UsePrivateIP = flags.Bool("hcloud-use-private-ip")
+	d.IPv6Only = flags.Bool("hcloud-ipv6-only")
+	d.PoweronTimeout = time.Duration(flags.Int("hcloud-poweron-timeout")) * time.Second
+
+	if d.UsePrivateIP && d.PrivateNetwork == "" {
+		return fmt.Errorf("--hcloud-use-private-ip requires --hcloud-private-network to be set")
+	}
+
+	if ids := flags.String("hcloud-ssh-key-ids"); ids != "" {
+		for _, id := range strings.Split(ids, ",") {
+			keyId, err := strconv.Atoi(strings.TrimSpace(id))
+			if err != nil {
+				return fmt.Errorf("--hcloud-ssh-key-ids: invalid key ID %q: %s", id, err)
+			}
+			d.SSHKeyIDs = append(d.SSHKeyIDs, keyId)
+		}
+	}
+
+	if names := flags.String("hcloud-ssh-key-names"); names != "" {
+		for _, name := range strings.Split(names, ",") {
+			d.SSHKeyNames = append(d.SSHKeyNames, strings.TrimSpace(name))
+		}
+	}
+
+	d.ExistingKeyPath = flags.String("hcloud-existing-key-path")
+	d.UserData = flags.String("hcloud-user-data")
+	d.UserDataFile = flags.String("hcloud-user-data-file")
+	d.PlacementGroup = flags.String("hcloud-placement-group")
+	d.Labels = flags.StringSlice("hcloud-labels")
+
+	if firewalls := flags.String("hcloud-firewalls"); firewalls != "" {
+		for _, firewall := range strings.Split(firewalls, ",") {
+			d.Firewalls = append(d.Firewalls, strings.TrimSpace(firewall))
+		}
+	}
+
+	d.RescueOnFailure = flags.Bool("hcloud-rescue-on-failure")
+
 	d.hcloud = hcloud.NewClient(hcloud.WithToken(token), hcloud.WithApplication("docker-machine", version.Version))
 	return nil
 }
@@ -215,11 +737,68 @@ func (d *Driver) Start() error {
 	if err != nil {
 		return err
 	}
-	action, _, err := d.hcloud.Server.Poweron(context.TODO(), server)
-	if err != nil {
-		return err
+
+	timeout := d.PoweronTimeout
+	if timeout == 0 {
+		timeout = defaultPoweronTimeout
+	}
+	start := time.Now()
+	deadline := start.Add(timeout)
+
+	for attempt := 1; attempt <= poweronMaxRetries; attempt++ {
+		action, _, err := d.hcloud.Server.Poweron(context.TODO(), server)
+		if err != nil {
+			return err
+		}
+		if err := d.waitOnAction(action); err != nil {
+			return err
+		}
+
+		attemptDeadline := start.Add(timeout * time.Duration(attempt) / poweronMaxRetries)
+		running, err := d.waitForServerRunning(server.ID, attemptDeadline)
+		if err != nil {
+			return err
+		}
+		if running {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+
+		log.Printf("HCloud server %d still reports %q after poweron action, retrying (%d/%d)", server.ID, hcloud.ServerStatusOff, attempt, poweronMaxRetries)
+	}
+
+	if d.RescueOnFailure {
+		log.Printf("HCloud server %d stuck off after %d poweron attempts, enabling rescue mode for recovery", server.ID, poweronMaxRetries)
+		if rescueErr := d.Rescue(defaultRescueOSType); rescueErr != nil {
+			log.Printf("HCloud rescue-mode recovery failed for server %d: %s", server.ID, rescueErr)
+		}
+	}
+
+	return ErrServerStuckOff
+}
+
+// waitForServerRunning polls the server until it leaves ServerStatusOff or
+// the given deadline passes. The HCloud API occasionally reports a
+// successful Poweron action while the server remains off, so this guards
+// against provisioning blocking forever on SSH. Start gives each of its
+// poweron retries an equal share of --hcloud-poweron-timeout, so the timeout
+// bounds the overall wait rather than each individual attempt.
+func (d *Driver) waitForServerRunning(serverId int, deadline time.Time) (bool, error) {
+	for {
+		server, _, err := d.hcloud.Server.GetByID(context.TODO(), serverId)
+		if err != nil {
+			return false, err
+		}
+		if server.Status != hcloud.ServerStatusOff {
+			return true, nil
+		}
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+		time.Sleep(poweronPollInterval)
 	}
-	return d.waitOnAction(action)
 }
 
 func (d *Driver) Stop() error {